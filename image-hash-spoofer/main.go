@@ -0,0 +1,244 @@
+// Command spoof mines invisible, format-preserving payloads for PNG, JPEG,
+// WebP, GIF, and PDF files so the resulting file's SHA-256 hash starts with
+// a chosen prefix.
+package main
+
+import (
+	"bytes"
+	"context"
+	cryptorand "crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/tony-nyagah/pesapal-junior-programmer-challenge-2024/image-hash-spoofer/pkg/spoof"
+)
+
+// checkpointInterval is how often, in attempts, a deterministic resumable
+// run persists its progress to the resume file.
+const checkpointInterval = 2_000_000
+
+// checkpoint is the JSON state persisted by -resume so a deterministic run
+// can pick up exactly where a previous invocation left off. Counters holds
+// each worker's own next-untried counter (indexed by worker ID), not a
+// single shared position: workers progress unevenly, so resuming every
+// worker from one shared counter would silently skip ranges of the nonce
+// space some worker hadn't reached yet.
+type checkpoint struct {
+	Seed     uint64   `json:"seed"`
+	Workers  int      `json:"workers"`
+	Counters []uint64 `json:"counters"`
+}
+
+// loadCheckpoint reads a checkpoint from path. A missing file is not an
+// error: it means this is a fresh run.
+func loadCheckpoint(path string) (*checkpoint, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resume file: %w", err)
+	}
+	var cp checkpoint
+	if err := json.Unmarshal(data, &cp); err != nil {
+		return nil, fmt.Errorf("failed to parse resume file: %w", err)
+	}
+	return &cp, nil
+}
+
+// saveCheckpoint writes cp to path, overwriting any existing checkpoint.
+func saveCheckpoint(path string, cp checkpoint) error {
+	data, err := json.Marshal(cp)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// randomSeed returns a seed suitable for a fresh deterministic run.
+func randomSeed() (uint64, error) {
+	var b [8]byte
+	if _, err := cryptorand.Read(b[:]); err != nil {
+		return 0, err
+	}
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// parseHexPrefix decodes a (possibly "0x"-prefixed, possibly odd-length) hex
+// string into the bytes spoof.Miner needs plus the number of significant
+// bits, so a prefix like "abc" targets 12 bits rather than being rounded up
+// to a whole byte.
+func parseHexPrefix(s string) ([]byte, int, error) {
+	s = strings.TrimPrefix(strings.ToLower(s), "0x")
+	bits := len(s) * 4
+	if len(s)%2 == 1 {
+		s += "0"
+	}
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, 0, fmt.Errorf("invalid hex prefix: %w", err)
+	}
+	return b, bits, nil
+}
+
+// spoofOptions bundles spoofFile's less-common flags so adding another one
+// doesn't grow the positional parameter list further.
+type spoofOptions struct {
+	workers                 int
+	stripMetadata, stripICC bool
+	deterministic           bool
+	seed                    uint64
+	resumePath              string
+}
+
+// spoofFile reads the input file, autodetects its container format from its
+// magic bytes, optionally strips pre-existing metadata, then mines a nonce
+// until the resulting file's hash starts with prefix. Since stripping
+// happens before hashing, both the printed "original" hash and the spoofed
+// output reflect the cleaned file.
+func spoofFile(prefixHex, inputPath, outputPath string, opts spoofOptions) error {
+	inputData, err := os.ReadFile(inputPath)
+	if err != nil {
+		return fmt.Errorf("failed to read input file: %w", err)
+	}
+
+	container, err := spoof.DetectContainer(inputData)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Detected format: %s\n", container.Name())
+
+	if opts.stripMetadata {
+		if inputData, err = spoof.StripMetadata(container, inputData, opts.stripICC); err != nil {
+			return fmt.Errorf("failed to strip metadata: %w", err)
+		}
+	}
+
+	originalHash := sha256.Sum256(inputData)
+	fmt.Printf("Original file hash: %s  %s\n", hex.EncodeToString(originalHash[:]), inputPath)
+
+	prefix, prefixBits, err := parseHexPrefix(prefixHex)
+	if err != nil {
+		return err
+	}
+
+	m := &spoof.Miner{
+		Prefix:     prefix,
+		PrefixBits: prefixBits,
+		Workers:    opts.workers,
+		Container:  container,
+	}
+
+	if opts.deterministic || opts.resumePath != "" {
+		seed := opts.seed
+		var startCounters []uint64
+		resumed := false
+		if opts.resumePath != "" {
+			cp, err := loadCheckpoint(opts.resumePath)
+			if err != nil {
+				return err
+			}
+			if cp != nil {
+				if cp.Workers != opts.workers {
+					return fmt.Errorf("checkpoint %s was created with -j %d, but this run uses -j %d; rerun with -j %d or delete the checkpoint", opts.resumePath, cp.Workers, opts.workers, cp.Workers)
+				}
+				seed = cp.Seed
+				startCounters = cp.Counters
+				resumed = true
+				fmt.Printf("Resuming from %s at counters %v\n", opts.resumePath, startCounters)
+			}
+		}
+		if seed == 0 && !resumed {
+			var err error
+			if seed, err = randomSeed(); err != nil {
+				return fmt.Errorf("failed to generate seed: %w", err)
+			}
+		}
+		m.Deterministic = true
+		m.Seed = seed
+		m.StartCounters = startCounters
+		if opts.resumePath != "" {
+			m.CheckpointEvery = checkpointInterval
+			m.OnCheckpoint = func(counters []uint64) {
+				cp := checkpoint{Seed: seed, Workers: opts.workers, Counters: counters}
+				if err := saveCheckpoint(opts.resumePath, cp); err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to save checkpoint: %v\n", err)
+				}
+			}
+		}
+	}
+
+	outData, stats, err := m.Mine(context.Background(), bytes.NewReader(inputData))
+	if err != nil {
+		return fmt.Errorf("mining failed: %w", err)
+	}
+
+	if err := os.WriteFile(outputPath, outData, 0644); err != nil {
+		return fmt.Errorf("failed to write output file: %w", err)
+	}
+
+	if opts.resumePath != "" {
+		if err := os.Remove(opts.resumePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			fmt.Fprintf(os.Stderr, "Warning: failed to remove resume file: %v\n", err)
+		}
+	}
+
+	resultHash := sha256.Sum256(outData)
+	fmt.Printf("Success after %d attempts across %d workers (%.0f hashes/sec)!\n", stats.Attempts, opts.workers, stats.HashesPerSec)
+	fmt.Printf("Resulting hash: %s  %s\n", hex.EncodeToString(resultHash[:]), outputPath)
+	return nil
+}
+
+// main is the entry point.
+// Usage: ./spoof [-j workers] [-keep-metadata] [-strip-icc] [-deterministic] [-seed n] [-resume file] <hex_prefix> <input_image> <output_image>
+// Example: ./spoof -j 8 0x24 original.png altered.png
+// This will attempt to find a file whose SHA-256 hash begins with "24".
+func main() {
+	workers := flag.Int("j", runtime.NumCPU(), "number of parallel mining workers")
+	strip := flag.Bool("strip", true, "strip EXIF/metadata before spoofing")
+	keepMetadata := flag.Bool("keep-metadata", false, "keep EXIF/metadata (overrides -strip)")
+	stripICC := flag.Bool("strip-icc", false, "also strip the ICC color profile from JPEGs")
+	deterministic := flag.Bool("deterministic", false, "derive nonces from -seed instead of crypto/rand, for a reproducible search")
+	seed := flag.Uint64("seed", 0, "seed for -deterministic (random if 0 and not resuming)")
+	resume := flag.String("resume", "", "periodically checkpoint to this file and resume from it on restart (implies -deterministic)")
+	flag.Parse()
+
+	args := flag.Args()
+	if len(args) < 3 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-j workers] [-keep-metadata] [-strip-icc] [-deterministic] [-seed n] [-resume file] <hex_prefix> <input_image> <output_image>\n", os.Args[0])
+		os.Exit(1)
+	}
+
+	prefixHex := args[0]
+	inputPath := args[1]
+	outputPath := args[2]
+
+	if *workers < 1 {
+		*workers = 1
+	}
+	if *keepMetadata {
+		*strip = false
+	}
+
+	opts := spoofOptions{
+		workers:       *workers,
+		stripMetadata: *strip,
+		stripICC:      *stripICC,
+		deterministic: *deterministic,
+		seed:          *seed,
+		resumePath:    *resume,
+	}
+
+	if err := spoofFile(prefixHex, inputPath, outputPath, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
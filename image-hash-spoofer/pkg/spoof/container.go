@@ -0,0 +1,53 @@
+package spoof
+
+import "errors"
+
+// Container abstracts the format-specific logic needed to mine a file: how
+// to recognize it from its magic bytes, and how to rewrite it so a 64-byte
+// nonce block can be mined without rehashing the rest of the file (see
+// miningLayout).
+type Container interface {
+	// Name returns a short, human-readable name for this format.
+	Name() string
+	// Detect reports whether data looks like a file of this format.
+	Detect(data []byte) bool
+	// Layout rewrites data to carry an invisible payload and returns where
+	// the mining nonce lives within it.
+	Layout(data []byte) (*miningLayout, error)
+}
+
+// defaultContainers lists the built-in backends in detection order. Formats
+// are told apart by magic bytes, not file extension, so the tool works on
+// arbitrary inputs.
+var defaultContainers = []Container{
+	pngContainer{},
+	jpegContainer{},
+	webpContainer{},
+	gifContainer{},
+	pdfContainer{},
+}
+
+// DetectContainer returns the first built-in container whose Detect matches
+// data.
+func DetectContainer(data []byte) (Container, error) {
+	for _, c := range defaultContainers {
+		if c.Detect(data) {
+			return c, nil
+		}
+	}
+	return nil, errors.New("unrecognized file format (supported: PNG, JPEG, WebP, GIF, PDF)")
+}
+
+// StripMetadata removes pre-existing EXIF/metadata from data using the
+// stripping logic for container's concrete format, if one exists. Formats
+// without a stripping implementation are returned unchanged.
+func StripMetadata(container Container, data []byte, stripICC bool) ([]byte, error) {
+	switch container.(type) {
+	case pngContainer:
+		return stripPNGMetadata(data)
+	case jpegContainer:
+		return stripJPEGMetadata(data, stripICC)
+	default:
+		return data, nil
+	}
+}
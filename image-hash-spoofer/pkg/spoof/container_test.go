@@ -0,0 +1,96 @@
+package spoof
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// validFixture builds a minimal, well-formed file for each container format.
+func validFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	switch name {
+	case "PNG":
+		var buf bytes.Buffer
+		buf.WriteString("\x89PNG\r\n\x1a\n")
+		buf.Write(encodePNGChunk("IHDR", make([]byte, 13)))
+		buf.Write(encodePNGChunk("IEND", nil))
+		return buf.Bytes()
+	case "JPEG":
+		return []byte{0xFF, 0xD8, 0xFF, 0xD9}
+	case "WebP":
+		return []byte("RIFF\x04\x00\x00\x00WEBP")
+	case "GIF":
+		return []byte("GIF89a\x3B")
+	case "PDF":
+		return []byte("%PDF-1.4\n%%EOF")
+	default:
+		t.Fatalf("no fixture for %s", name)
+		return nil
+	}
+}
+
+func TestDetectContainer(t *testing.T) {
+	for _, c := range defaultContainers {
+		c := c
+		t.Run(c.Name(), func(t *testing.T) {
+			data := validFixture(t, c.Name())
+			got, err := DetectContainer(data)
+			if err != nil {
+				t.Fatalf("DetectContainer: %v", err)
+			}
+			if got.Name() != c.Name() {
+				t.Fatalf("DetectContainer picked %s, want %s", got.Name(), c.Name())
+			}
+		})
+	}
+}
+
+// TestContainerLayoutAlignment checks the invariant every Layout
+// implementation promises: prefix always lands the nonce block on a
+// SHA-256 block boundary.
+func TestContainerLayoutAlignment(t *testing.T) {
+	for _, c := range defaultContainers {
+		c := c
+		t.Run(c.Name(), func(t *testing.T) {
+			layout, err := c.Layout(validFixture(t, c.Name()))
+			if err != nil {
+				t.Fatalf("Layout: %v", err)
+			}
+			if len(layout.prefix)%64 != 0 {
+				t.Fatalf("len(prefix) = %d, not a multiple of 64", len(layout.prefix))
+			}
+		})
+	}
+}
+
+// TestWebPLayoutRejectsOddLength guards the fix for a silent misalignment
+// bug: an odd-length WebP input can never satisfy the block-boundary
+// invariant above, so Layout must reject it instead of mining against a
+// misaligned prefix.
+func TestWebPLayoutRejectsOddLength(t *testing.T) {
+	odd := append(validFixture(t, "WebP"), 0x00)
+	if _, err := (webpContainer{}).Layout(odd); err == nil {
+		t.Fatal("Layout accepted an odd-length WebP file, want error")
+	}
+}
+
+// TestMinerMineRoundTrip exercises the full midstate-and-assemble pipeline
+// per format: with a zero-bit prefix every attempt matches immediately, so
+// this mainly checks that Layout/assemble produce a file the container
+// still recognizes.
+func TestMinerMineRoundTrip(t *testing.T) {
+	for _, c := range defaultContainers {
+		c := c
+		t.Run(c.Name(), func(t *testing.T) {
+			m := &Miner{Workers: 1, Container: c, Deterministic: true, Seed: 1}
+			out, _, err := m.Mine(context.Background(), bytes.NewReader(validFixture(t, c.Name())))
+			if err != nil {
+				t.Fatalf("Mine: %v", err)
+			}
+			if !c.Detect(out) {
+				t.Fatalf("mined output no longer detected as %s", c.Name())
+			}
+		})
+	}
+}
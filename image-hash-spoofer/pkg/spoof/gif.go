@@ -0,0 +1,57 @@
+package spoof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+type gifContainer struct{}
+
+func (gifContainer) Name() string { return "GIF" }
+
+func (gifContainer) Detect(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("GIF87a")) || bytes.HasPrefix(data, []byte("GIF89a"))
+}
+
+// Layout inserts an Application Extension block immediately before the GIF
+// trailer (0x3B), padding with a filler extension beforehand if needed so
+// the new block's 64-byte data sub-block lands on a SHA-256 block boundary.
+func (gifContainer) Layout(data []byte) (*miningLayout, error) {
+	if !(gifContainer{}).Detect(data) {
+		return nil, errors.New("invalid GIF file")
+	}
+	if len(data) == 0 || data[len(data)-1] != 0x3B {
+		return nil, errors.New("GIF trailer (0x3B) not found")
+	}
+	trailerPos := len(data) - 1
+
+	const appID = "SPOOFDATA01" // 11 bytes, as required by the Application Extension format
+	const headerLen = 2 + 1 + len(appID) + 1    // intro+label, block size, app id, sub-block size byte
+	const overhead = 2 + 1 + len(appID) + 1 + 1 // headerLen plus the sub-block terminator
+
+	prefix := append([]byte{}, data[:trailerPos]...)
+	if fillerLen := computeFillerLen(trailerPos, headerLen, overhead); fillerLen > 0 {
+		fillerData := make([]byte, fillerLen-overhead)
+		prefix = append(prefix, 0x21, 0xFF, 0x0B)
+		prefix = append(prefix, []byte(appID)...)
+		prefix = append(prefix, byte(len(fillerData)))
+		prefix = append(prefix, fillerData...)
+		prefix = append(prefix, 0x00) // sub-block terminator
+	}
+
+	prefix = append(prefix, 0x21, 0xFF, 0x0B)
+	prefix = append(prefix, []byte(appID)...)
+	prefix = append(prefix, byte(sha256.BlockSize))
+
+	tail := []byte{0x00} // sub-block terminator
+	tail = append(tail, data[trailerPos:]...)
+
+	return &miningLayout{
+		prefix: prefix,
+		fixed:  make([]byte, fixedSize),
+		buildTail: func(block []byte) []byte {
+			return tail
+		},
+	}, nil
+}
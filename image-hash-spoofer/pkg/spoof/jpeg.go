@@ -0,0 +1,105 @@
+package spoof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+)
+
+type jpegContainer struct{}
+
+func (jpegContainer) Name() string { return "JPEG" }
+
+func (jpegContainer) Detect(data []byte) bool {
+	return len(data) >= 2 && data[0] == 0xFF && data[1] == 0xD8
+}
+
+func (jpegContainer) Layout(data []byte) (*miningLayout, error) {
+	return buildJPEGLayout(data)
+}
+
+// buildJPEGLayout inserts a COM (comment) segment just before the EOI marker,
+// padding with a filler COM segment beforehand if needed, so the new
+// segment's 64-byte data lands on a SHA-256 block boundary and only EOI
+// follows it.
+func buildJPEGLayout(data []byte) (*miningLayout, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, errors.New("not a valid JPEG (no SOI)")
+	}
+	if data[len(data)-2] != 0xFF || data[len(data)-1] != 0xD9 {
+		return nil, errors.New("not a valid JPEG (no EOI)")
+	}
+
+	const headerLen = 4        // FF FE + 2-byte length, precedes the segment's data
+	insertPos := len(data) - 2 // right before EOI
+
+	prefix := append([]byte{}, data[:insertPos]...)
+	if fillerLen := computeFillerLen(insertPos, headerLen, 4); fillerLen > 0 {
+		fillerData := make([]byte, fillerLen-4)
+		prefix = append(prefix, 0xFF, 0xFE, byte((fillerLen-2)>>8), byte((fillerLen-2)&0xFF))
+		prefix = append(prefix, fillerData...)
+	}
+
+	segLen := sha256.BlockSize + 2
+	prefix = append(prefix, 0xFF, 0xFE, byte(segLen>>8), byte(segLen&0xFF))
+
+	tail := append([]byte{}, data[insertPos:]...)
+	return &miningLayout{
+		prefix: prefix,
+		fixed:  make([]byte, fixedSize),
+		buildTail: func(block []byte) []byte {
+			return tail
+		},
+	}, nil
+}
+
+// stripJPEGMetadata removes APP1 (EXIF/XMP) and APP13 (IPTC/Photoshop)
+// segments from a JPEG, and APP2 (ICC profile) as well if stripICC is set.
+// Everything else, including the entropy-coded scan data, is copied as-is.
+func stripJPEGMetadata(data []byte, stripICC bool) ([]byte, error) {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return nil, errors.New("not a valid JPEG (no SOI)")
+	}
+
+	var out bytes.Buffer
+	out.Write(data[:2]) // SOI
+	pos := 2
+	for pos < len(data) {
+		if data[pos] != 0xFF {
+			out.Write(data[pos:])
+			break
+		}
+		marker := data[pos+1]
+
+		// Markers with no payload (standalone markers, RSTn).
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD9) {
+			out.Write(data[pos : pos+2])
+			pos += 2
+			if marker == 0xD9 { // EOI
+				break
+			}
+			continue
+		}
+
+		if pos+4 > len(data) {
+			return nil, errors.New("truncated JPEG segment")
+		}
+		segLen := int(data[pos+2])<<8 | int(data[pos+3])
+		segEnd := pos + 2 + segLen
+		if segEnd > len(data) {
+			return nil, errors.New("truncated JPEG segment")
+		}
+
+		drop := marker == 0xE1 || marker == 0xED || (stripICC && marker == 0xE2)
+		if !drop {
+			out.Write(data[pos:segEnd])
+		}
+		pos = segEnd
+
+		if marker == 0xDA { // start of scan: the rest is entropy-coded image data
+			out.Write(data[pos:])
+			break
+		}
+	}
+	return out.Bytes(), nil
+}
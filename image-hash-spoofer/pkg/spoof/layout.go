@@ -0,0 +1,53 @@
+package spoof
+
+import "crypto/sha256"
+
+const (
+	nonceSize = 32 // random bytes we control per attempt
+	fixedSize = sha256.BlockSize - nonceSize
+)
+
+// miningLayout splits a rewritten file into the part that never changes
+// across mining attempts (prefix, already hashed once) and the single
+// 64-byte SHA-256 block that carries the nonce we vary. Anything after that
+// block (buildTail) is still written on every attempt, but it's typically a
+// handful of bytes (a chunk CRC and a trailer) rather than the whole file.
+type miningLayout struct {
+	prefix    []byte                    // len(prefix) % sha256.BlockSize == 0
+	fixed     []byte                    // fixedSize bytes following the nonce inside the block
+	buildTail func(block []byte) []byte // bytes written after the block; may depend on its content
+}
+
+// block returns the 64-byte SHA-256 block for a given nonce.
+func (l *miningLayout) block(nonce []byte) []byte {
+	b := make([]byte, sha256.BlockSize)
+	copy(b, nonce)
+	copy(b[nonceSize:], l.fixed)
+	return b
+}
+
+// assemble reconstitutes the full output file for a given nonce. Only called
+// once a match is found, not on every attempt.
+func (l *miningLayout) assemble(nonce []byte) []byte {
+	block := l.block(nonce)
+	out := make([]byte, 0, len(l.prefix)+len(block)+64)
+	out = append(out, l.prefix...)
+	out = append(out, block...)
+	out = append(out, l.buildTail(block)...)
+	return out
+}
+
+// computeFillerLen returns how many bytes of filler data a container needs
+// to insert (in a record with the given per-record overhead) so that a
+// following record's header of length headerLen, currently starting at
+// offset, ends up with its body aligned on a SHA-256 block boundary.
+func computeFillerLen(offset, headerLen, overhead int) int {
+	need := (sha256.BlockSize - (offset+headerLen)%sha256.BlockSize) % sha256.BlockSize
+	if need == 0 {
+		return 0
+	}
+	for need < overhead {
+		need += sha256.BlockSize
+	}
+	return need
+}
@@ -0,0 +1,96 @@
+package spoof
+
+import (
+	"bytes"
+	"testing"
+)
+
+// buildJPEGSegment encodes a JPEG marker segment: FF <marker> <len hi> <len
+// lo> <data>, where len counts the two length bytes plus data.
+func buildJPEGSegment(marker byte, data []byte) []byte {
+	segLen := len(data) + 2
+	out := []byte{0xFF, marker, byte(segLen >> 8), byte(segLen & 0xFF)}
+	return append(out, data...)
+}
+
+func TestStripPNGMetadata(t *testing.T) {
+	var input []byte
+	input = append(input, "\x89PNG\r\n\x1a\n"...)
+	input = append(input, encodePNGChunk("IHDR", make([]byte, 13))...)
+	input = append(input, encodePNGChunk("eXIf", []byte("fake exif GPS data"))...)
+	input = append(input, encodePNGChunk("tEXt", []byte("Comment\x00hello"))...)
+	pixelData := []byte("pretend compressed pixel data")
+	input = append(input, encodePNGChunk("IDAT", pixelData)...)
+	input = append(input, encodePNGChunk("IEND", nil)...)
+
+	out, err := stripPNGMetadata(input)
+	if err != nil {
+		t.Fatalf("stripPNGMetadata: %v", err)
+	}
+
+	for _, removed := range [][]byte{[]byte("fake exif GPS data"), []byte("hello")} {
+		if bytes.Contains(out, removed) {
+			t.Errorf("stripped output still contains %q", removed)
+		}
+	}
+	if !bytes.Contains(out, pixelData) {
+		t.Error("stripped output lost the IDAT pixel data")
+	}
+	if !(pngContainer{}).Detect(out) {
+		t.Error("stripped output is no longer a detectable PNG")
+	}
+}
+
+func TestStripJPEGMetadata(t *testing.T) {
+	exif := append([]byte("Exif\x00\x00"), []byte("fake GPS coordinates")...)
+	iptc := append([]byte("Photoshop 3.0\x00"), []byte("fake IPTC caption")...)
+	icc := append([]byte("ICC_PROFILE\x00"), []byte("fake ICC profile bytes")...)
+	scanData := []byte("pretend entropy-coded pixel data")
+
+	build := func() []byte {
+		var data []byte
+		data = append(data, 0xFF, 0xD8) // SOI
+		data = append(data, buildJPEGSegment(0xE1, exif)...)
+		data = append(data, buildJPEGSegment(0xED, iptc)...)
+		data = append(data, buildJPEGSegment(0xE2, icc)...)
+		data = append(data, buildJPEGSegment(0xDA, []byte{0x00})...) // SOS
+		data = append(data, scanData...)
+		data = append(data, 0xFF, 0xD9) // EOI
+		return data
+	}
+
+	t.Run("default strips EXIF and IPTC, keeps ICC", func(t *testing.T) {
+		out, err := stripJPEGMetadata(build(), false)
+		if err != nil {
+			t.Fatalf("stripJPEGMetadata: %v", err)
+		}
+		if bytes.Contains(out, exif) {
+			t.Error("stripped output still contains APP1/EXIF data")
+		}
+		if bytes.Contains(out, iptc) {
+			t.Error("stripped output still contains APP13/IPTC data")
+		}
+		if !bytes.Contains(out, icc) {
+			t.Error("stripped output lost the ICC profile, but stripICC was false")
+		}
+		if !bytes.Contains(out, scanData) {
+			t.Error("stripped output lost the entropy-coded scan data")
+		}
+		if !(jpegContainer{}).Detect(out) {
+			t.Error("stripped output is no longer a detectable JPEG")
+		}
+	})
+
+	t.Run("stripICC also removes the ICC profile", func(t *testing.T) {
+		out, err := stripJPEGMetadata(build(), true)
+		if err != nil {
+			t.Fatalf("stripJPEGMetadata: %v", err)
+		}
+		if bytes.Contains(out, icc) {
+			t.Error("stripped output still contains the ICC profile with stripICC set")
+		}
+		if !bytes.Contains(out, scanData) {
+			t.Error("stripped output lost the entropy-coded scan data")
+		}
+	})
+}
@@ -0,0 +1,330 @@
+// Package spoof mines invisible, format-preserving payloads for PNG, JPEG,
+// WebP, GIF, and PDF files so the resulting file's SHA-256 hash starts with
+// a chosen bit prefix.
+package spoof
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Stats reports how a Mine call went.
+type Stats struct {
+	Attempts     uint64
+	Elapsed      time.Duration
+	HashesPerSec float64
+}
+
+// Miner mines a nonce that, once embedded in an input file via Container,
+// makes the file's SHA-256 hash start with Prefix.
+type Miner struct {
+	// Prefix is the target hash prefix. Only the first PrefixBits bits of it
+	// are significant.
+	Prefix []byte
+	// PrefixBits is the number of leading bits of Prefix to match, allowing
+	// bit-level granularity (e.g. 17 leading zero bits) rather than being
+	// stuck at 4-bit hex-nibble boundaries.
+	PrefixBits int
+	// Workers is the number of parallel mining goroutines. Defaults to 1 if
+	// less than 1.
+	Workers int
+	// Container picks the file format backend. If nil, Mine autodetects it
+	// from the input's magic bytes via DetectContainer.
+	Container Container
+	// Rand is the source of randomness for nonces in the default,
+	// non-deterministic mode. Defaults to crypto/rand.Reader if nil.
+	Rand io.Reader
+
+	// Deterministic, if true, derives nonces from Seed and a counter instead
+	// of Rand, so a run can be reproduced or resumed exactly.
+	Deterministic bool
+	// Seed selects the deterministic nonce sequence.
+	Seed uint64
+	// StartCounter offsets every worker's starting counter by the same
+	// amount for a fresh deterministic run; worker i starts at
+	// StartCounter+i. Ignored when len(StartCounters) equals the worker
+	// count. It cannot express
+	// a correct resume point on its own once workers have progressed
+	// unevenly; use StartCounters for that.
+	StartCounter uint64
+	// StartCounters, if its length equals Workers, gives each worker i its
+	// own starting counter explicitly, overriding StartCounter. Pass the
+	// slice captured by a prior OnCheckpoint call to resume a deterministic
+	// run without skipping or repeating any worker's nonces.
+	StartCounters []uint64
+
+	// CheckpointEvery, if nonzero in Deterministic mode, calls OnCheckpoint
+	// roughly every CheckpointEvery attempts with every worker's own
+	// next-untried counter, so a caller can persist it and resume later via
+	// StartCounters.
+	CheckpointEvery uint64
+	// OnCheckpoint is invoked periodically during Mine; see CheckpointEvery.
+	OnCheckpoint func(counters []uint64)
+}
+
+// Mine reads in fully, lays it out for mining, and searches for a nonce
+// whose embedding makes the result's SHA-256 hash match Prefix/PrefixBits.
+// It stops early if ctx is cancelled.
+func (m *Miner) Mine(ctx context.Context, in io.Reader) ([]byte, Stats, error) {
+	data, err := io.ReadAll(in)
+	if err != nil {
+		return nil, Stats{}, fmt.Errorf("failed to read input: %w", err)
+	}
+
+	container := m.Container
+	if container == nil {
+		container, err = DetectContainer(data)
+		if err != nil {
+			return nil, Stats{}, err
+		}
+	}
+
+	layout, err := container.Layout(data)
+	if err != nil {
+		return nil, Stats{}, fmt.Errorf("failed to lay out %s for mining: %w", container.Name(), err)
+	}
+
+	workers := m.Workers
+	if workers < 1 {
+		workers = 1
+	}
+
+	start := time.Now()
+	result, attempts, err := mine(ctx, layout, m.Prefix, m.PrefixBits, workers, m)
+	if err != nil {
+		return nil, Stats{Attempts: attempts}, err
+	}
+	elapsed := time.Since(start)
+
+	stats := Stats{Attempts: attempts, Elapsed: elapsed}
+	if elapsed > 0 {
+		stats.HashesPerSec = float64(attempts) / elapsed.Seconds()
+	}
+	return layout.assemble(result.nonce), stats, nil
+}
+
+// matchesPrefix reports whether sum's leading prefixBits bits equal prefix's.
+func matchesPrefix(sum, prefix []byte, prefixBits int) bool {
+	fullBytes := prefixBits / 8
+	if fullBytes > len(sum) || fullBytes > len(prefix) {
+		return false
+	}
+	if !bytes.Equal(sum[:fullBytes], prefix[:fullBytes]) {
+		return false
+	}
+	if rem := prefixBits % 8; rem > 0 {
+		if fullBytes >= len(sum) || fullBytes >= len(prefix) {
+			return false
+		}
+		mask := byte(0xFF << (8 - rem))
+		if sum[fullBytes]&mask != prefix[fullBytes]&mask {
+			return false
+		}
+	}
+	return true
+}
+
+// miningResult is sent back by whichever worker finds a matching nonce.
+type miningResult struct {
+	nonce []byte
+	hash  [sha256.Size]byte
+}
+
+// midstate snapshots a hash.Hash after it has consumed prefix, so every
+// worker can clone it instead of rehashing the unchanging prefix on every
+// attempt.
+func midstate(prefix []byte) ([]byte, error) {
+	h := sha256.New()
+	h.Write(prefix)
+	marshaler, ok := h.(encoding.BinaryMarshaler)
+	if !ok {
+		return nil, errors.New("crypto/sha256 hasher does not support state snapshots")
+	}
+	return marshaler.MarshalBinary()
+}
+
+// nonceSource yields successive nonces for one worker.
+type nonceSource func() ([]byte, error)
+
+// randomNonceSource draws nonces straight from randSrc.
+func randomNonceSource(randSrc io.Reader) nonceSource {
+	return func() ([]byte, error) {
+		nonce := make([]byte, nonceSize)
+		if _, err := io.ReadFull(randSrc, nonce); err != nil {
+			return nil, err
+		}
+		return nonce, nil
+	}
+}
+
+// counterNonceSource derives nonces deterministically from seed and a
+// counter that starts at `start` and strides by `stride` on every call, so
+// concurrent workers starting at different offsets never collide. If
+// progress is non-nil, it is atomically updated after every call to hold
+// the next counter this source hasn't tried yet, so a caller can read it
+// from another goroutine and later resume this exact worker from that
+// value without skipping or repeating any counter.
+func counterNonceSource(seed, start, stride uint64, progress *uint64) nonceSource {
+	counter := start
+	if progress != nil {
+		atomic.StoreUint64(progress, counter)
+	}
+	return func() ([]byte, error) {
+		var in [16]byte
+		binary.BigEndian.PutUint64(in[0:8], seed)
+		binary.BigEndian.PutUint64(in[8:16], counter)
+		counter += stride
+		if progress != nil {
+			atomic.StoreUint64(progress, counter)
+		}
+		sum := sha256.Sum256(in[:])
+		return sum[:nonceSize], nil
+	}
+}
+
+// mineWorker repeatedly clones the prefix midstate, writes a 64-byte block
+// plus the fixed tail, and checks whether the resulting hash matches
+// prefix/prefixBits. It hashes one 64-byte block plus the short tail per
+// attempt, regardless of the file's size.
+func mineWorker(ctx context.Context, state []byte, layout *miningLayout, prefix []byte, prefixBits int, nextNonce nonceSource, attempts *uint64, results chan<- miningResult) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+		}
+
+		nonce, err := nextNonce()
+		if err != nil {
+			return
+		}
+		block := layout.block(nonce)
+
+		h := sha256.New()
+		// state always comes from this package's own midstate(), so
+		// UnmarshalBinary cannot fail in practice; the error is ignored
+		// rather than threaded through every worker's hot loop.
+		_ = h.(encoding.BinaryUnmarshaler).UnmarshalBinary(state)
+		h.Write(block)
+		h.Write(layout.buildTail(block))
+		sum := h.Sum(nil)
+
+		atomic.AddUint64(attempts, 1)
+
+		if matchesPrefix(sum, prefix, prefixBits) {
+			var fixed [sha256.Size]byte
+			copy(fixed[:], sum)
+			select {
+			case results <- miningResult{nonce: append([]byte{}, nonce...), hash: fixed}:
+			case <-ctx.Done():
+			}
+			return
+		}
+	}
+}
+
+// mine fans the search for a matching nonce out across workers goroutines,
+// cancelling the rest as soon as one of them finds a match.
+func mine(ctx context.Context, layout *miningLayout, prefix []byte, prefixBits, workers int, m *Miner) (*miningResult, uint64, error) {
+	state, err := midstate(layout.prefix)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan miningResult, workers)
+	var attempts uint64
+	// workerCounters[i] tracks worker i's own next-untried counter, so a
+	// checkpoint snapshot can resume each worker's exact strided sequence
+	// instead of restarting every worker from one shared, meaningless
+	// global position (see counterNonceSource).
+	workerCounters := make([]uint64, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		var nextNonce nonceSource
+		if m.Deterministic {
+			start := uint64(i)
+			if len(m.StartCounters) == workers {
+				start = m.StartCounters[i]
+			} else {
+				start += m.StartCounter
+			}
+			// Worker i covers start, start+workers, start+2*workers, ...
+			// so the nonce space is partitioned without any
+			// cross-worker coordination.
+			nextNonce = counterNonceSource(m.Seed, start, uint64(workers), &workerCounters[i])
+		} else {
+			randSrc := m.Rand
+			if randSrc == nil {
+				randSrc = rand.Reader
+			}
+			nextNonce = randomNonceSource(randSrc)
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mineWorker(ctx, state, layout, prefix, prefixBits, nextNonce, &attempts, results)
+		}()
+	}
+
+	if m.Deterministic && m.CheckpointEvery > 0 && m.OnCheckpoint != nil {
+		go runCheckpoints(ctx, &attempts, workerCounters, m.CheckpointEvery, m.OnCheckpoint)
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	result, ok := <-results
+	cancel()
+	for range results {
+		// Drain so the remaining workers' sends don't block after we stop reading.
+	}
+	if !ok {
+		return nil, atomic.LoadUint64(&attempts), errors.New("all workers stopped without finding a match")
+	}
+	return &result, atomic.LoadUint64(&attempts), nil
+}
+
+// runCheckpoints polls attempts and, once it has advanced by at least every
+// attempts, calls onCheckpoint with a snapshot of every worker's own
+// next-untried counter (see workerCounters in mine), until ctx is
+// cancelled. Resuming from this snapshot continues each worker's strided
+// sequence exactly, rather than restarting every worker from one shared
+// position derived from the global attempt total.
+func runCheckpoints(ctx context.Context, attempts *uint64, workerCounters []uint64, every uint64, onCheckpoint func([]uint64)) {
+	ticker := time.NewTicker(500 * time.Millisecond)
+	defer ticker.Stop()
+
+	var lastReported uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n := atomic.LoadUint64(attempts)
+			if n-lastReported >= every {
+				lastReported = n
+				snapshot := make([]uint64, len(workerCounters))
+				for i := range workerCounters {
+					snapshot[i] = atomic.LoadUint64(&workerCounters[i])
+				}
+				onCheckpoint(snapshot)
+			}
+		}
+	}
+}
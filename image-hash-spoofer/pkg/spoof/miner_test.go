@@ -0,0 +1,125 @@
+package spoof
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// TestCounterNonceSourceResumeIsGapFree guards the bug a deterministic
+// resume must never reintroduce: a worker's sequence, broken at an
+// arbitrary point and resumed from the progress value captured at that
+// point, must produce exactly the nonces an uninterrupted run would have
+// produced from there on — no skipped counters, no repeats.
+func TestCounterNonceSourceResumeIsGapFree(t *testing.T) {
+	const seed, start, stride = 7, 3, 5
+	const firstRunCalls = 4
+
+	reference := counterNonceSource(seed, start, stride, nil)
+	var want [][]byte
+	for i := 0; i < firstRunCalls*2; i++ {
+		n, err := reference()
+		if err != nil {
+			t.Fatalf("reference call %d: %v", i, err)
+		}
+		want = append(want, n)
+	}
+
+	var progress uint64
+	interrupted := counterNonceSource(seed, start, stride, &progress)
+	var gotFirstHalf [][]byte
+	for i := 0; i < firstRunCalls; i++ {
+		n, err := interrupted()
+		if err != nil {
+			t.Fatalf("interrupted call %d: %v", i, err)
+		}
+		gotFirstHalf = append(gotFirstHalf, n)
+	}
+
+	resumed := counterNonceSource(seed, progress, stride, nil)
+	var gotSecondHalf [][]byte
+	for i := 0; i < firstRunCalls; i++ {
+		n, err := resumed()
+		if err != nil {
+			t.Fatalf("resumed call %d: %v", i, err)
+		}
+		gotSecondHalf = append(gotSecondHalf, n)
+	}
+
+	got := append(gotFirstHalf, gotSecondHalf...)
+	for i := range want {
+		if !bytes.Equal(got[i], want[i]) {
+			t.Fatalf("nonce %d diverged after resume: got %x, want %x", i, got[i], want[i])
+		}
+	}
+}
+
+// TestMineResumeContinuesPerWorkerSequence checks that a checkpoint
+// snapshot captures each worker's own counter, not one shared position, so
+// resuming from it keeps every worker on the same residue class it was
+// already exploring.
+func TestMineResumeContinuesPerWorkerSequence(t *testing.T) {
+	const workers = 3
+	data := validFixture(t, "PDF")
+
+	// A prefix this specific is never going to be found in the short time
+	// this test runs for; the mining loop keeps going until ctx is
+	// cancelled, which is exactly what we want to observe checkpoints.
+	unreachablePrefix := []byte{0xDE, 0xAD, 0xBE, 0xEF}
+
+	captureCheckpoint := func(startCounters []uint64) []uint64 {
+		ctx, cancel := context.WithTimeout(context.Background(), 750*time.Millisecond)
+		defer cancel()
+
+		var mu sync.Mutex
+		var snapshot []uint64
+		var got bool
+		m := &Miner{
+			Prefix:          unreachablePrefix,
+			PrefixBits:      32,
+			Workers:         workers,
+			Deterministic:   true,
+			Seed:            99,
+			StartCounters:   startCounters,
+			CheckpointEvery: 1,
+			OnCheckpoint: func(counters []uint64) {
+				mu.Lock()
+				defer mu.Unlock()
+				snapshot = append([]uint64{}, counters...)
+				got = true
+			},
+		}
+		_, _, err := m.Mine(ctx, bytes.NewReader(data))
+		if err == nil {
+			t.Fatal("Mine unexpectedly found a match for an unreachable prefix")
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if !got {
+			t.Fatal("no checkpoint was captured before ctx expired")
+		}
+		return snapshot
+	}
+
+	first := captureCheckpoint(nil)
+	if len(first) != workers {
+		t.Fatalf("len(first) = %d, want %d", len(first), workers)
+	}
+	for i, c := range first {
+		if (c-uint64(i))%workers != 0 {
+			t.Fatalf("worker %d counter %d is not on its own residue class (start %d, stride %d)", i, c, i, workers)
+		}
+	}
+
+	second := captureCheckpoint(first)
+	for i := range second {
+		if second[i] < first[i] {
+			t.Fatalf("worker %d counter went backwards on resume: %d -> %d", i, first[i], second[i])
+		}
+		if (second[i]-first[i])%workers != 0 {
+			t.Fatalf("worker %d resumed off its residue class: %d -> %d", i, first[i], second[i])
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package spoof
+
+import (
+	"bytes"
+	"errors"
+)
+
+type pdfContainer struct{}
+
+func (pdfContainer) Name() string { return "PDF" }
+
+func (pdfContainer) Detect(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("%PDF-"))
+}
+
+// Layout appends our nonce block after the end of the file, preceded by
+// zero bytes up to the next 64-byte boundary. Most
+// PDF readers parse from the trailer backwards and ignore trailing bytes,
+// so no incremental-update bookkeeping is required.
+func (pdfContainer) Layout(data []byte) (*miningLayout, error) {
+	if !(pdfContainer{}).Detect(data) {
+		return nil, errors.New("invalid PDF file")
+	}
+
+	prefix := append([]byte{}, data...)
+	if fillerLen := computeFillerLen(len(prefix), 0, 0); fillerLen > 0 {
+		prefix = append(prefix, make([]byte, fillerLen)...)
+	}
+
+	return &miningLayout{
+		prefix: prefix,
+		fixed:  make([]byte, fixedSize),
+		buildTail: func(block []byte) []byte {
+			return nil
+		},
+	}, nil
+}
@@ -0,0 +1,124 @@
+package spoof
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash/crc32"
+
+	pngstructure "github.com/dsoprea/go-png-image-structure/v2"
+)
+
+type pngContainer struct{}
+
+func (pngContainer) Name() string { return "PNG" }
+
+func (pngContainer) Detect(data []byte) bool {
+	return bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n"))
+}
+
+func (pngContainer) Layout(data []byte) (*miningLayout, error) {
+	return buildPNGLayout(data)
+}
+
+// encodePNGChunk builds a complete PNG chunk (length + type + data + CRC).
+func encodePNGChunk(chunkType string, chunkData []byte) []byte {
+	var buf bytes.Buffer
+	buf.Write(toBigEndian(uint32(len(chunkData))))
+	buf.WriteString(chunkType)
+	buf.Write(chunkData)
+	buf.Write(toBigEndian(crc32.ChecksumIEEE(append([]byte(chunkType), chunkData...))))
+	return buf.Bytes()
+}
+
+// findIENDOffset parses data with go-png-image-structure, which verifies the
+// PNG signature and each chunk's CRC as it walks the file, then returns the
+// byte offset of the IEND chunk. Corrupt PNGs are rejected here rather than
+// silently producing output that only differs by our injected chunk.
+func findIENDOffset(data []byte) (int, error) {
+	chunks, err := pngstructure.NewPngMediaParser().ParseBytes(data)
+	if err != nil {
+		return -1, fmt.Errorf("corrupt or invalid PNG: %w", err)
+	}
+
+	cs := chunks.(*pngstructure.ChunkSlice)
+	for _, c := range cs.Chunks() {
+		if c.Type == "IEND" {
+			return c.Offset, nil
+		}
+	}
+	return -1, errors.New("IEND chunk not found in PNG")
+}
+
+// buildPNGLayout inserts a new ancillary chunk before IEND, padding with a
+// filler chunk beforehand if needed, so the new chunk's 64-byte data field
+// lands on a SHA-256 block boundary.
+//
+// The chunk type "spFx" encodes PNG's four naming bits correctly: ancillary
+// (lowercase first letter, safe to drop), private (lowercase second letter,
+// not registered), reserved (uppercase third letter, per spec), safe-to-copy
+// (lowercase fourth letter, so image editors don't strip it on re-save).
+func buildPNGLayout(data []byte) (*miningLayout, error) {
+	iendPos, err := findIENDOffset(data)
+	if err != nil {
+		return nil, err
+	}
+
+	const chunkType = "spFx"
+	const headerLen = 8 // length + type, precedes the chunk's data field
+
+	prefix := append([]byte{}, data[:iendPos]...)
+	if fillerLen := computeFillerLen(iendPos, headerLen, 12); fillerLen > 0 {
+		prefix = append(prefix, encodePNGChunk("spFa", make([]byte, fillerLen-12))...)
+	}
+
+	typeBytes := []byte(chunkType)
+	prefix = append(prefix, toBigEndian(sha256.BlockSize)...)
+	prefix = append(prefix, typeBytes...)
+
+	tail := append([]byte{}, data[iendPos:]...)
+	return &miningLayout{
+		prefix: prefix,
+		fixed:  make([]byte, fixedSize),
+		buildTail: func(block []byte) []byte {
+			crc := crc32.ChecksumIEEE(append(append([]byte{}, typeBytes...), block...))
+			out := append([]byte{}, toBigEndian(crc)...)
+			return append(out, tail...)
+		},
+	}, nil
+}
+
+// pngStrippedChunkTypes lists PNG chunks that can carry personal metadata
+// (GPS coordinates, camera serials, free-text comments, timestamps) and are
+// safe to drop without affecting the displayed image.
+var pngStrippedChunkTypes = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"iTXt": true,
+	"zTXt": true,
+	"tIME": true,
+}
+
+// stripPNGMetadata removes EXIF and text/timestamp chunks from a PNG,
+// leaving the image data and everything else untouched.
+func stripPNGMetadata(data []byte) ([]byte, error) {
+	parsed, err := pngstructure.NewPngMediaParser().ParseBytes(data)
+	if err != nil {
+		return nil, fmt.Errorf("corrupt or invalid PNG: %w", err)
+	}
+	cs := parsed.(*pngstructure.ChunkSlice)
+
+	kept := make([]*pngstructure.Chunk, 0, len(cs.Chunks()))
+	for _, c := range cs.Chunks() {
+		if !pngStrippedChunkTypes[c.Type] {
+			kept = append(kept, c)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pngstructure.NewChunkSlice(kept).WriteTo(&buf); err != nil {
+		return nil, fmt.Errorf("failed to rebuild PNG: %w", err)
+	}
+	return buf.Bytes(), nil
+}
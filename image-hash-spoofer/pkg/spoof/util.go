@@ -0,0 +1,17 @@
+package spoof
+
+// toBigEndian converts a uint32 to a 4-byte big-endian slice.
+func toBigEndian(val uint32) []byte {
+	b := make([]byte, 4)
+	b[0] = byte((val >> 24) & 0xFF)
+	b[1] = byte((val >> 16) & 0xFF)
+	b[2] = byte((val >> 8) & 0xFF)
+	b[3] = byte(val & 0xFF)
+	return b
+}
+
+// toLittleEndian converts a uint32 to a 4-byte little-endian slice, as used
+// by RIFF (WebP) chunk headers.
+func toLittleEndian(val uint32) []byte {
+	return []byte{byte(val), byte(val >> 8), byte(val >> 16), byte(val >> 24)}
+}
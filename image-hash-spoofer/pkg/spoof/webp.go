@@ -0,0 +1,65 @@
+package spoof
+
+import (
+	"crypto/sha256"
+	"errors"
+)
+
+type webpContainer struct{}
+
+func (webpContainer) Name() string { return "WebP" }
+
+func (webpContainer) Detect(data []byte) bool {
+	return len(data) >= 12 && string(data[0:4]) == "RIFF" && string(data[8:12]) == "WEBP"
+}
+
+// Layout appends a junk RIFF chunk to the end of the container, padding
+// with a filler chunk beforehand if needed so the new chunk's 64-byte data
+// lands on a SHA-256 block boundary. RIFF chunks run to EOF with no
+// trailer, so the whole original file becomes part of the prefix and the
+// outer RIFF size field is patched once the final length is known.
+func (webpContainer) Layout(data []byte) (*miningLayout, error) {
+	if !(webpContainer{}).Detect(data) {
+		return nil, errors.New("invalid WebP file")
+	}
+	if len(data)%2 == 1 {
+		// Every valid RIFF chunk occupies an even number of bytes, so a
+		// conformant file is always even length. An odd length can't be
+		// reached by any chunk-aligned insertion, which would silently
+		// defeat the block-alignment computeFillerLen relies on below.
+		return nil, errors.New("invalid WebP file: odd length (RIFF requires even-length files)")
+	}
+
+	const chunkID = "jUNK" // unregistered FourCC; RIFF readers skip chunks they don't recognize
+	const headerLen = 8    // FourCC + chunk size, precedes the chunk's data
+
+	prefix := append([]byte{}, data...)
+	if fillerLen := computeFillerLen(len(prefix), headerLen, headerLen); fillerLen > 0 {
+		fillerData := make([]byte, fillerLen-headerLen)
+		prefix = append(prefix, []byte(chunkID)...)
+		prefix = append(prefix, toLittleEndian(uint32(len(fillerData)))...)
+		prefix = append(prefix, fillerData...)
+		if len(fillerData)%2 == 1 {
+			prefix = append(prefix, 0x00) // RIFF chunks are padded to an even length
+		}
+	}
+
+	prefix = append(prefix, []byte(chunkID)...)
+	prefix = append(prefix, toLittleEndian(sha256.BlockSize)...)
+
+	var tail []byte
+	if sha256.BlockSize%2 == 1 {
+		tail = append(tail, 0x00)
+	}
+
+	totalLen := len(prefix) + sha256.BlockSize + len(tail)
+	copy(prefix[4:8], toLittleEndian(uint32(totalLen-8)))
+
+	return &miningLayout{
+		prefix: prefix,
+		fixed:  make([]byte, fixedSize),
+		buildTail: func(block []byte) []byte {
+			return tail
+		},
+	}, nil
+}